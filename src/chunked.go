@@ -0,0 +1,192 @@
+package src
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/cheggaaa/pb/v3"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+)
+
+// httpRange is a half-open byte range [start, end) fetched with a single
+// Range request.
+type httpRange struct {
+	start int64
+	end   int64
+}
+
+// probeRange learns the size of url and whether the server honors byte range
+// requests. It prefers a HEAD request and falls back to a 1-byte Range GET
+// for servers that don't implement HEAD.
+func probeRange(url string) (size int64, rangeable bool, err error) {
+	response, err := http.Head(url)
+	if err == nil && response.StatusCode < 400 {
+		defer response.Body.Close()
+		return response.ContentLength, response.Header.Get("Accept-Ranges") == "bytes", nil
+	}
+
+	request, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return 0, false, err
+	}
+	request.Header.Set("Range", "bytes=0-0")
+
+	response, err = http.DefaultClient.Do(request)
+	if err != nil {
+		return 0, false, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusPartialContent {
+		return 0, false, nil
+	}
+
+	total, err := parseContentRangeTotal(response.Header.Get("Content-Range"))
+	if err != nil {
+		return 0, false, nil
+	}
+	return total, true, nil
+}
+
+// parseContentRangeTotal extracts the total size from a "bytes start-end/total" header.
+func parseContentRangeTotal(header string) (int64, error) {
+	var start, end, total int64
+	if _, err := fmt.Sscanf(header, "bytes %d-%d/%d", &start, &end, &total); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// planRanges splits size bytes into min(chunksPerFile, ceil(size/chunkSize))
+// contiguous half-open ranges of near-equal length; the last range may be
+// shorter. When chunksPerFile caps the count, each range is larger than
+// chunkSize.
+func planRanges(size int64, chunkSize int64, chunksPerFile int64) []httpRange {
+	n := int64(math.Ceil(float64(size) / float64(chunkSize)))
+	if n > chunksPerFile {
+		n = chunksPerFile
+	}
+	if n < 1 {
+		n = 1
+	}
+
+	perChunk := int64(math.Ceil(float64(size) / float64(n)))
+	ranges := make([]httpRange, 0, n)
+	for start := int64(0); start < size; start += perChunk {
+		end := start + perChunk
+		if end > size {
+			end = size
+		}
+		ranges = append(ranges, httpRange{start: start, end: end})
+	}
+	return ranges
+}
+
+// fetchRange downloads a single range of url and writes it into out at the
+// matching offset, reporting bytes read through bar.
+func fetchRange(ctx context.Context, url string, out *os.File, r httpRange, bar *pb.ProgressBar) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", r.start, r.end-1))
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("server refused range bytes=%d-%d (status %d)", r.start, r.end-1, response.StatusCode)
+	}
+
+	var reader io.Reader = response.Body
+	if bar != nil {
+		reader = bar.NewProxyReader(response.Body)
+	}
+
+	buf := make([]byte, 32*1024)
+	offset := r.start
+	for {
+		n, rerr := reader.Read(buf)
+		if n > 0 {
+			if _, werr := out.WriteAt(buf[:n], offset); werr != nil {
+				return werr
+			}
+			offset += int64(n)
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			return rerr
+		}
+	}
+
+	downloadEvent("chunk_complete", url, out.Name(), r.end-r.start, 0, 0)
+	return nil
+}
+
+// downloadFileChunked downloads url into dest by splitting it across parallel
+// Range requests bounded by sem, pre-allocating the destination file and
+// writing each chunk at its own offset. Each chunk is retried independently,
+// whatever its error, up to retries times before the whole download is given
+// up on.
+//
+// The caller is expected to hold one dispatch permit on sem (acquired before
+// probing the URL) covering the file staging below; it is released here once
+// the .part file is created and truncated, freeing that permit for a range
+// fetch instead.
+func downloadFileChunked(ctx context.Context, url string, dest string, size int64, ranges []httpRange, sem *semaphore.Weighted, bar *pb.ProgressBar, retries int, backoff time.Duration, maxBackoff time.Duration) error {
+	partPath := dest + ".part"
+	out, err := os.Create(partPath)
+	if err != nil {
+		sem.Release(1)
+		return err
+	}
+
+	if err := out.Truncate(size); err != nil {
+		out.Close()
+		os.Remove(partPath)
+		sem.Release(1)
+		return err
+	}
+	sem.Release(1)
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	for _, r := range ranges {
+		r := r
+		group.Go(func() error {
+			if err := sem.Acquire(groupCtx, 1); err != nil {
+				return err
+			}
+			defer sem.Release(1)
+			return retryRange(groupCtx, retries, backoff, maxBackoff, func() error {
+				return fetchRange(groupCtx, url, out, r, bar)
+			})
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		out.Close()
+		os.Remove(partPath)
+		return err
+	}
+
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(partPath, dest)
+}
@@ -0,0 +1,98 @@
+package src
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanRanges(t *testing.T) {
+	tests := []struct {
+		name          string
+		size          int64
+		chunkSize     int64
+		chunksPerFile int64
+		want          []httpRange
+	}{
+		{
+			name:          "evenly divisible",
+			size:          100,
+			chunkSize:     25,
+			chunksPerFile: 10,
+			want: []httpRange{
+				{start: 0, end: 25},
+				{start: 25, end: 50},
+				{start: 50, end: 75},
+				{start: 75, end: 100},
+			},
+		},
+		{
+			name:          "remainder trails in the last range",
+			size:          90,
+			chunkSize:     25,
+			chunksPerFile: 10,
+			want: []httpRange{
+				{start: 0, end: 23},
+				{start: 23, end: 46},
+				{start: 46, end: 69},
+				{start: 69, end: 90},
+			},
+		},
+		{
+			name:          "chunksPerFile caps the chunk count",
+			size:          100,
+			chunkSize:     10,
+			chunksPerFile: 2,
+			want: []httpRange{
+				{start: 0, end: 50},
+				{start: 50, end: 100},
+			},
+		},
+		{
+			name:          "size smaller than chunk size yields a single range",
+			size:          5,
+			chunkSize:     25,
+			chunksPerFile: 10,
+			want:          []httpRange{{start: 0, end: 5}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := planRanges(tt.size, tt.chunkSize, tt.chunksPerFile)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("planRanges(%d, %d, %d) = %v, want %v", tt.size, tt.chunkSize, tt.chunksPerFile, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseContentRangeTotal(t *testing.T) {
+	tests := []struct {
+		header  string
+		want    int64
+		wantErr bool
+	}{
+		{header: "bytes 0-99/200", want: 200},
+		{header: "bytes 100-199/200", want: 200},
+		{header: "", wantErr: true},
+		{header: "bytes */200", wantErr: true},
+		{header: "not a content-range header", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseContentRangeTotal(tt.header)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseContentRangeTotal(%q) expected an error, got total %d", tt.header, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseContentRangeTotal(%q) unexpected error: %v", tt.header, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseContentRangeTotal(%q) = %d, want %d", tt.header, got, tt.want)
+		}
+	}
+}
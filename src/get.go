@@ -4,16 +4,23 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
-	"net/http"
 	"net/url"
 	"os"
+	"os/signal"
 	"path/filepath"
-	"strings"
 	"sync"
+	"syscall"
+	"time"
 
+	"code.cloudfoundry.org/bytefmt"
 	"github.com/caffeine-addictt/video-manager/src/utils"
+	"github.com/cheggaaa/pb/v3"
+	"github.com/gofrs/flock"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
 )
 
 // Strategy
@@ -22,6 +29,7 @@ type strategyEnum string
 const (
 	strategySynchronous strategyEnum = "synchronous"
 	strategyConcurrent  strategyEnum = "concurrent"
+	strategyChunked     strategyEnum = "chunked"
 )
 
 func (e *strategyEnum) String() string {
@@ -30,11 +38,11 @@ func (e *strategyEnum) String() string {
 
 func (e *strategyEnum) Set(value string) error {
 	switch value {
-	case "concurrent", "synchronous":
+	case "concurrent", "synchronous", "chunked":
 		*e = strategyEnum(value)
 		return nil
 	default:
-		return errors.New("must be one of 'synchronous' or 'concurrent'")
+		return errors.New("must be one of 'synchronous', 'concurrent' or 'chunked'")
 	}
 }
 
@@ -42,11 +50,58 @@ func (e *strategyEnum) Type() string {
 	return "strategy"
 }
 
+// byteSizeFlag parses human-readable sizes like "10MiB" for --chunk-size.
+type byteSizeFlag int64
+
+func (b *byteSizeFlag) String() string {
+	return bytefmt.ByteSize(uint64(*b))
+}
+
+func (b *byteSizeFlag) Set(value string) error {
+	n, err := bytefmt.ToBytes(value)
+	if err != nil {
+		return err
+	}
+	*b = byteSizeFlag(n)
+	return nil
+}
+
+func (b *byteSizeFlag) Type() string {
+	return "size"
+}
+
+// progressBarTemplate renders bytes transferred, rate and ETA.
+const progressBarTemplate = `{{ string . "prefix" }}{{ bar . "[" "=" ">" " " "]" }} {{ counters . }} {{ speed . }} {{ rtime . "ETA %s" }}`
+
+// newProgressBar builds a bytes-aware bar for a single download. total <= 0
+// switches the bar into pb's indeterminate mode.
+func newProgressBar(prefix string, total int64) *pb.ProgressBar {
+	bar := pb.New64(total)
+	bar.Set(pb.Bytes, true)
+	bar.Set("prefix", prefix+" ")
+	bar.SetTemplateString(progressBarTemplate)
+	// pb renders to stderr by default; pin it to stdout, and route it through
+	// the same write lock the logger uses (outputWriter) so a bar repaint and
+	// a log line can't garble each other on a shared terminal.
+	bar.SetWriter(outputWriter(os.Stdout))
+	return bar
+}
+
 // Command stuff
 var getFlags struct {
-	inputFile      string
-	strategy       strategyEnum
-	maxConcurrency int64
+	inputFile       string
+	strategy        strategyEnum
+	maxConcurrency  int64
+	chunkSize       byteSizeFlag
+	chunksPerFile   int64
+	tags            []string
+	notags          []string
+	integrityAlgo   string
+	resume          bool
+	force           bool
+	retries         int
+	retryBackoff    time.Duration
+	retryMaxBackoff time.Duration
 }
 
 var getCommand = &cobra.Command{
@@ -54,125 +109,245 @@ var getCommand = &cobra.Command{
 	Short: "Get and download videos",
 	Long:  `Get and download videos from passed file and url(s)`,
 	Run: func(cmd *cobra.Command, args []string) {
+		// Cancel the root context on Ctrl-C / SIGTERM so in-flight downloads can
+		// abort cleanly instead of leaving half-written files behind
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
 		// Warn on inefficient settings
 		if getFlags.maxConcurrency == 1 && getFlags.strategy == strategyConcurrent {
-			fmt.Println("WARNING: Setting -m to 1 with -s concurrent may not be efficient, please consider using -s synchronous instead.")
+			Warn("Setting -m to 1 with -s concurrent may not be efficient, please consider using -s synchronous instead.")
 		}
 
 		// Validate working directory exists and is writable
 		dirPath, err := utils.ValidateDirectory(workingDir)
 		if err != nil {
-			fmt.Printf("Failed to validate working directory: %#v\n", workingDir)
+			Error(fmt.Sprintf("Failed to validate working directory: %#v", workingDir))
 			Debug(err.Error())
 			os.Exit(1)
 		}
 
-		// Turn all URLS to a map to eliminate duplicates
-		// We map string: struct{} for the smallest memory footprint
-		argSet := make(map[string]struct{})
+		// Turn all URLs into manifest entries to eliminate duplicates, keyed by
+		// URL for the smallest lookup footprint
+		targets := make(map[string]manifestEntry)
 		for _, arg := range args {
-			argSet[arg] = struct{}{}
+			targets[arg] = manifestEntry{URL: arg}
 		}
 
 		// Validate explicitly passed URL(s)
-		if len(argSet) > 0 {
+		if len(targets) > 0 {
 			Debug("Validating passed URL(s)")
-			for rawURL := range argSet {
+			for rawURL := range targets {
 				if _, err := url.ParseRequestURI(rawURL); err != nil {
-					fmt.Println("Invalid URL: " + rawURL)
+					Error("Invalid URL: " + rawURL)
 					os.Exit(1)
 				}
 			}
 		}
 
-		// Fetch URLs from file
+		// Fetch URLs from file, either a plain one-URL-per-line list or a
+		// structured manifest carrying filenames, integrity and tags
 		if getFlags.inputFile != "" {
 			Debug("-f was passed, reading url(s) from file")
 			file, err := os.Open(getFlags.inputFile)
 			if err != nil {
-				fmt.Printf("Failed to read file at %s\n", getFlags.inputFile)
+				Error(fmt.Sprintf("Failed to read file at %s", getFlags.inputFile))
 				Debug(err.Error())
 				os.Exit(1)
 			}
 			Debug("Closing file at " + getFlags.inputFile)
 			defer file.Close()
 
-			// Read URLs from file, line by line
-			preURLCount := len(argSet)
-			scanner := bufio.NewScanner(file)
-			for scanner.Scan() {
-				// Ignore duplicates
-				if _, ok := argSet[scanner.Text()]; ok {
-					Info("Skipping duplicate URL: " + scanner.Text())
-					continue
-				}
+			preURLCount := len(targets)
 
-				// Validate URL
-				if _, err := url.ParseRequestURI(scanner.Text()); err != nil {
-					fmt.Println("Invalid URL: " + scanner.Text())
+			firstLine, err := bufio.NewReader(file).ReadString('\n')
+			if err != nil && err != io.EOF {
+				Error(fmt.Sprintf("Failed to read file at %s", getFlags.inputFile))
+				Debug(err.Error())
+				os.Exit(1)
+			}
+			if _, err := file.Seek(0, io.SeekStart); err != nil {
+				Error(fmt.Sprintf("Failed to read file at %s", getFlags.inputFile))
+				Debug(err.Error())
+				os.Exit(1)
+			}
+
+			if isManifestFile(getFlags.inputFile, firstLine) {
+				Debug("Detected structured manifest at " + getFlags.inputFile)
+				entries, err := readManifest(file)
+				if err != nil {
+					Error(fmt.Sprintf("Failed to parse manifest at %s", getFlags.inputFile))
+					Debug(err.Error())
 					os.Exit(1)
 				}
-				argSet[scanner.Text()] = struct{}{}
+
+				for _, entry := range entries {
+					if _, err := url.ParseRequestURI(entry.URL); err != nil {
+						Error("Invalid URL in manifest: " + entry.URL)
+						os.Exit(1)
+					}
+					if !matchesTags(entry, getFlags.tags, getFlags.notags) {
+						Info("Skipping " + entry.URL + " (tag filter)")
+						continue
+					}
+					if _, ok := targets[entry.URL]; ok {
+						Info("Skipping duplicate URL: " + entry.URL)
+						continue
+					}
+					targets[entry.URL] = entry
+				}
+			} else {
+				// Read URLs from file, line by line
+				scanner := bufio.NewScanner(file)
+				for scanner.Scan() {
+					// Ignore duplicates
+					if _, ok := targets[scanner.Text()]; ok {
+						Info("Skipping duplicate URL: " + scanner.Text())
+						continue
+					}
+
+					// Validate URL
+					if _, err := url.ParseRequestURI(scanner.Text()); err != nil {
+						Error("Invalid URL: " + scanner.Text())
+						os.Exit(1)
+					}
+					targets[scanner.Text()] = manifestEntry{URL: scanner.Text()}
+				}
 			}
-			Info("Read " + fmt.Sprint(len(args)-preURLCount) + " url(s) from " + getFlags.inputFile)
+			Info("Read " + fmt.Sprint(len(targets)-preURLCount) + " url(s) from " + getFlags.inputFile)
 		}
 
 		// Ensure a URL was passed
-		if len(argSet) == 0 {
-			fmt.Println("No URL(s) were passed! See -h|--help for usage.")
+		if len(targets) == 0 {
+			Error("No URL(s) were passed! See -h|--help for usage.")
 			os.Exit(1)
 		}
 
-		downloadFile := func(url string) {
-			split := strings.Split(url, "/")
-			downloadLocation := filepath.Clean(filepath.Join(dirPath, split[len(split)-1]))
+		// downloadFileUnlocked runs the single-stream download body without taking
+		// the destination lock itself, so a caller that already holds it (the
+		// chunked strategy's non-rangeable fallback) can invoke it directly
+		// instead of re-locking the same path and deadlocking against itself.
+		downloadFileUnlocked := func(entry manifestEntry, downloadLocation string, partLocation string, bar *pb.ProgressBar) {
+			url := entry.URL
+
+			// -resume picks up from an existing .part file by asking the server
+			// for the remaining bytes
+			var startOffset int64
+			openFlag := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+			if getFlags.resume {
+				if info, err := os.Stat(partLocation); err == nil {
+					startOffset = info.Size()
+					openFlag = os.O_WRONLY | os.O_APPEND
+				}
+			}
 
-			// Ensure file already does not exist
-			Info("Checking if " + downloadLocation + " already exists")
-			if _, err := os.Stat(downloadLocation); err == nil {
-				fmt.Printf("File already exists for %s\n", downloadLocation)
-				Debug("File: " + downloadLocation + " already exists for " + url)
+			// Write to a .part file so a partially downloaded file can never
+			// masquerade as complete
+			Info("Writing to .part file at: " + partLocation)
+			out, err := os.OpenFile(partLocation, openFlag, 0o644)
+			if err != nil {
+				Error(fmt.Sprintf("Failed to create file at: %s", partLocation))
+				Debug(err.Error())
 				return
 			}
+			defer out.Close()
 
-			// Get File
-			fmt.Printf("Downloading %s to %s\n", url, downloadLocation)
-			Info("Getting url: " + url)
+			// Compute the digest while streaming so we don't have to re-read the
+			// file afterwards; a resumed download must first fold in the bytes
+			// it already has on disk
+			var digest hash.Hash
+			if entry.Integrity != "" {
+				algo, _, err := splitIntegrity(entry.Integrity, getFlags.integrityAlgo)
+				if err != nil {
+					Error(err.Error())
+					return
+				}
+				digest, err = newDigestHash(algo)
+				if err != nil {
+					Error(err.Error())
+					return
+				}
+				if startOffset > 0 {
+					if err := hashExistingPart(partLocation, digest); err != nil {
+						Error("Failed to hash existing .part file: " + partLocation)
+						Debug(err.Error())
+						return
+					}
+				}
+			}
 
-			request, err := http.NewRequest(http.MethodGet, url, http.NoBody)
-			if err != nil {
-				fmt.Println("Failed to create request: " + url)
+			bar.SetCurrent(startOffset)
+			bar.Set("prefix", filepath.Base(downloadLocation)+" ")
+			bar.Start()
+
+			// Get File, retrying transient failures with exponential backoff
+			started := time.Now()
+			downloadEvent("start", url, downloadLocation, startOffset, 0, 0)
+			if err := fetchWithRetry(ctx, url, out, &startOffset, bar, digest, getFlags.retries, getFlags.retryBackoff, getFlags.retryMaxBackoff); err != nil {
+				bar.Finish()
+				if ctx.Err() != nil {
+					Info("Download of " + url + " was cancelled, removing .part file")
+					out.Close()
+					os.Remove(partLocation)
+				}
+				Error("Failed to download: " + url)
 				Debug(err.Error())
 				return
 			}
+			bar.Finish()
+
+			if digest != nil {
+				_, expected, _ := splitIntegrity(entry.Integrity, getFlags.integrityAlgo)
+				if actual := fmt.Sprintf("%x", digest.Sum(nil)); actual != expected {
+					out.Close()
+					os.Remove(partLocation)
+					Error(fmt.Sprintf("Digest mismatch for %s: expected %s, got %s", url, expected, actual))
+					return
+				}
+				downloadEvent("digest_ok", url, downloadLocation, startOffset, time.Since(started), 0)
+			}
 
-			response, err := http.DefaultClient.Do(request)
-			if err != nil {
-				fmt.Println("Failed to get url: " + url)
+			// Only becomes the final file once the body (and digest, if any) are
+			// known-good
+			if err := out.Close(); err != nil {
+				Error(fmt.Sprintf("Failed to flush file at: %s", partLocation))
 				Debug(err.Error())
 				return
 			}
-			defer response.Body.Close()
-
-			// Create file
-			Info("Creating file at: " + downloadLocation)
-			out, err := os.Create(downloadLocation)
-			if err != nil {
-				fmt.Println("Failed to create file at: " + downloadLocation)
+			if err := os.Rename(partLocation, downloadLocation); err != nil {
+				Error(fmt.Sprintf("Failed to move %s to %s", partLocation, downloadLocation))
 				Debug(err.Error())
 				return
 			}
-			defer out.Close()
 
-			// Write to file
-			Info("Writing " + url + " to " + downloadLocation)
-			if _, err := io.Copy(out, response.Body); err != nil {
-				fmt.Printf("Failed to write to file at: %s\n", downloadLocation)
+			downloadEvent("written", url, downloadLocation, startOffset, time.Since(started), 0)
+		}
+
+		// downloadFile resolves the destination, skips it if already present,
+		// then holds the advisory lock on it for the whole download so a second
+		// `video-manager get` targeting the same file blocks instead of racing
+		// with us.
+		downloadFile := func(entry manifestEntry, bar *pb.ProgressBar) {
+			downloadLocation := resolveDownloadLocation(dirPath, entry)
+			partLocation := downloadLocation + ".part"
+
+			// Ensure file already does not exist, unless -force was passed
+			Info("Checking if " + downloadLocation + " already exists")
+			if _, err := os.Stat(downloadLocation); err == nil && !getFlags.force {
+				Info(fmt.Sprintf("File already exists for %s", downloadLocation))
+				Debug("File: " + downloadLocation + " already exists for " + entry.URL)
+				return
+			}
+
+			lock := flock.New(downloadLocation + ".lock")
+			if err := lock.Lock(); err != nil {
+				Error("Failed to lock " + downloadLocation)
 				Debug(err.Error())
 				return
 			}
+			defer lock.Unlock()
 
-			fmt.Println("Downloaded " + url + " to " + downloadLocation)
+			downloadFileUnlocked(entry, downloadLocation, partLocation, bar)
 		}
 
 		// Handle downloading
@@ -180,37 +355,69 @@ var getCommand = &cobra.Command{
 		case strategyConcurrent:
 			var waitGroup sync.WaitGroup
 
-			// Concurrency with no limit
+			// Concurrency with no limit: one bar per URL, all rendered together
 			if getFlags.maxConcurrency == 0 {
-				fmt.Println("Downloading concurrently... [No limit]")
-				waitGroup.Add(len(argSet))
+				Info("Downloading concurrently... [No limit]")
+
+				entries := make([]manifestEntry, 0, len(targets))
+				for _, entry := range targets {
+					entries = append(entries, entry)
+				}
+
+				bars := make([]*pb.ProgressBar, len(entries))
+				for i := range entries {
+					bars[i] = newProgressBar(entries[i].URL, 0)
+				}
+
+				pool, err := pb.StartPool(bars...)
+				if err != nil {
+					Error("Failed to start progress bar pool")
+					Debug(err.Error())
+					os.Exit(1)
+				}
+				defer pool.Stop()
 
-				for url := range argSet {
-					go func(url string) {
+				waitGroup.Add(len(entries))
+				for i, entry := range entries {
+					go func(entry manifestEntry, bar *pb.ProgressBar) {
 						defer waitGroup.Done()
-						downloadFile(url)
-					}(url)
+						downloadFile(entry, bar)
+					}(entry, bars[i])
 				}
 
-				// Concurrency with limit
+				// Concurrency with limit: one bar per worker slot, reused across
+				// whatever URLs that worker ends up pulling off the channel
 			} else {
-				fmt.Printf("Downloading concurrently... [Max: %d]\n", getFlags.maxConcurrency)
+				Info(fmt.Sprintf("Downloading concurrently... [Max: %d]", getFlags.maxConcurrency))
 				waitGroup.Add(int(getFlags.maxConcurrency))
 
+				bars := make([]*pb.ProgressBar, getFlags.maxConcurrency)
+				for i := range bars {
+					bars[i] = newProgressBar(fmt.Sprintf("slot %d", i+1), 0)
+				}
+
+				pool, err := pb.StartPool(bars...)
+				if err != nil {
+					Error("Failed to start progress bar pool")
+					Debug(err.Error())
+					os.Exit(1)
+				}
+				defer pool.Stop()
+
 				// Establish channel and workers
-				ch := make(chan string)
+				ch := make(chan manifestEntry)
 				for t := 0; t < int(getFlags.maxConcurrency); t++ {
-					go func() {
-						for url := range ch {
-							downloadFile(url)
+					go func(bar *pb.ProgressBar) {
+						for entry := range ch {
+							downloadFile(entry, bar)
 						}
 
 						waitGroup.Done()
-					}()
+					}(bars[t])
 				}
 
-				for url := range argSet {
-					ch <- url
+				for _, entry := range targets {
+					ch <- entry
 				}
 
 				close(ch)
@@ -218,30 +425,160 @@ var getCommand = &cobra.Command{
 
 			waitGroup.Wait()
 		case strategySynchronous:
-			fmt.Println("Downloading synchronously...")
+			Info("Downloading synchronously...")
+
+			// A single bar re-used across every URL
+			bar := newProgressBar("", 0)
+			pool, err := pb.StartPool(bar)
+			if err != nil {
+				Error("Failed to start progress bar")
+				Debug(err.Error())
+				os.Exit(1)
+			}
+			defer pool.Stop()
 
-			for url := range argSet {
-				downloadFile(url)
+			for _, entry := range targets {
+				downloadFile(entry, bar)
+			}
+		case strategyChunked:
+			Info("Downloading in parallel chunks...")
+
+			// Overall concurrency is shared between across-file dispatch and
+			// within-file range workers
+			limit := getFlags.maxConcurrency
+			if limit <= 0 {
+				limit = int64(len(targets)) * getFlags.chunksPerFile
+			}
+			sem := semaphore.NewWeighted(limit)
+
+			entries := make([]manifestEntry, 0, len(targets))
+			for _, entry := range targets {
+				entries = append(entries, entry)
+			}
+
+			bars := make([]*pb.ProgressBar, len(entries))
+			for i := range entries {
+				bars[i] = newProgressBar(entries[i].URL, 0)
+			}
+
+			pool, err := pb.StartPool(bars...)
+			if err != nil {
+				Error("Failed to start progress bar pool")
+				Debug(err.Error())
+				os.Exit(1)
+			}
+			defer pool.Stop()
+
+			group, groupCtx := errgroup.WithContext(ctx)
+			for i, entry := range entries {
+				entry, bar := entry, bars[i]
+				group.Go(func() error {
+					url := entry.URL
+					downloadLocation := resolveDownloadLocation(dirPath, entry)
+
+					if _, err := os.Stat(downloadLocation); err == nil && !getFlags.force {
+						Info(fmt.Sprintf("File already exists for %s", downloadLocation))
+						return nil
+					}
+
+					lock := flock.New(downloadLocation + ".lock")
+					if err := lock.Lock(); err != nil {
+						return fmt.Errorf("%s: failed to lock %s: %w", url, downloadLocation, err)
+					}
+					defer lock.Unlock()
+
+					// Gate dispatch itself on the shared semaphore, not just the
+					// per-range fetches, so --max-concurrency also bounds how many
+					// files are probed and pre-allocated at once. The permit is
+					// released once the .part file is staged (by downloadFileChunked)
+					// or immediately below if we fall back to single-stream.
+					if err := sem.Acquire(groupCtx, 1); err != nil {
+						return err
+					}
+
+					size, rangeable, err := probeRange(url)
+					if err != nil {
+						sem.Release(1)
+						return fmt.Errorf("%s: %w", url, err)
+					}
+					if !rangeable || size <= 0 {
+						sem.Release(1)
+						Info("Server does not support Range requests for " + url + ", falling back to single-stream download")
+						// The lock above is already held, so call the unlocked inner
+						// download directly: downloadFile would try to flock the same
+						// path again and deadlock against ourselves.
+						downloadFileUnlocked(entry, downloadLocation, downloadLocation+".part", bar)
+						return nil
+					}
+
+					ranges := planRanges(size, int64(getFlags.chunkSize), getFlags.chunksPerFile)
+					bar.SetCurrent(0)
+					bar.SetTotal(size)
+					bar.Set("prefix", filepath.Base(downloadLocation)+" ")
+					bar.Start()
+					defer bar.Finish()
+
+					started := time.Now()
+					downloadEvent("start", url, downloadLocation, size, 0, 0)
+					if err := downloadFileChunked(groupCtx, url, downloadLocation, size, ranges, sem, bar, getFlags.retries, getFlags.retryBackoff, getFlags.retryMaxBackoff); err != nil {
+						return fmt.Errorf("%s: %w", url, err)
+					}
+
+					if entry.Integrity != "" {
+						if err := verifyFileIntegrity(downloadLocation, entry.Integrity, getFlags.integrityAlgo); err != nil {
+							os.Remove(downloadLocation)
+							return fmt.Errorf("%s: %w", url, err)
+						}
+						downloadEvent("digest_ok", url, downloadLocation, size, time.Since(started), 0)
+					}
+
+					downloadEvent("written", url, downloadLocation, size, time.Since(started), 0)
+					return nil
+				})
+			}
+
+			if err := group.Wait(); err != nil {
+				Error("One or more chunked downloads failed")
+				Debug(err.Error())
+				os.Exit(1)
 			}
 		}
+
+		if ctx.Err() != nil {
+			Error("Aborted: " + ctx.Err().Error())
+			os.Exit(1)
+		}
 	},
 }
 
 func init() {
 	getFlags.strategy = strategyConcurrent
+	getFlags.chunkSize = byteSizeFlag(10 * 1024 * 1024)
+	getFlags.chunksPerFile = 4
+	getFlags.integrityAlgo = "sha256"
 
 	rootCommand.AddCommand(getCommand)
 	getCommand.Flags().StringVarP(&getFlags.inputFile, "file", "f", "", "Path to the input file containing the url(s)")
 	getCommand.Flags().Int64VarP(&getFlags.maxConcurrency, "max-concurrency", "m", 10, "Maximum number of concurrent downloads [0 = unlimited] (default is 10)")
 	getCommand.Flags().VarP(&getFlags.strategy, "strategy", "s", "Strategy to use when downloading (default is concurrent)")
+	getCommand.Flags().VarP(&getFlags.chunkSize, "chunk-size", "", "Target size of each Range request when using the 'chunked' strategy, e.g. 10MiB; ranges grow larger when --chunks-per-file caps their count (default is 10MiB)")
+	getCommand.Flags().Int64VarP(&getFlags.chunksPerFile, "chunks-per-file", "", 4, "Maximum number of parallel Range requests per file when using the 'chunked' strategy (default is 4)")
+	getCommand.Flags().StringArrayVarP(&getFlags.tags, "tag", "", nil, "Only download manifest entries carrying this tag (repeatable)")
+	getCommand.Flags().StringArrayVarP(&getFlags.notags, "notag", "", nil, "Skip manifest entries carrying this tag (repeatable)")
+	getCommand.Flags().StringVarP(&getFlags.integrityAlgo, "integrity-algo", "", "sha256", "Default digest algorithm for manifest entries without an explicit 'algo:' prefix")
+	getCommand.Flags().BoolVarP(&getFlags.resume, "resume", "", false, "Resume an interrupted download from its .part file, if one exists")
+	getCommand.Flags().BoolVarP(&getFlags.force, "force", "", false, "Overwrite the destination file if it already exists")
+	getCommand.Flags().IntVarP(&getFlags.retries, "retries", "", 3, "Number of times to retry a failed download")
+	getCommand.Flags().DurationVarP(&getFlags.retryBackoff, "retry-backoff", "", time.Second, "Initial backoff duration between retries")
+	getCommand.Flags().DurationVarP(&getFlags.retryMaxBackoff, "retry-max-backoff", "", 30*time.Second, "Maximum backoff duration between retries")
 	if err := getCommand.RegisterFlagCompletionFunc("strategy", strategyCompletion); err != nil {
-		fmt.Println("Failed to register completion for flag -s in get command")
+		Error("Failed to register completion for flag -s in get command")
 		Debug(err.Error())
 		os.Exit(1)
 	}
 
 	if err := getCommand.MarkFlagFilename("file"); err != nil {
-		fmt.Println("Failed to mark flag -f as filename in get command")
+		Error("Failed to mark flag -f as filename in get command")
 		Debug(err.Error())
 		os.Exit(1)
 	}
@@ -251,5 +588,6 @@ func strategyCompletion(_ *cobra.Command, _ []string, _ string) ([]string, cobra
 	return []string{
 		"synchronous\tDownload videos sequentially",
 		"concurrent\tDownload videos concurrently DEFAULT",
+		"chunked\tDownload each video as parallel HTTP Range requests",
 	}, cobra.ShellCompDirectiveNoFileComp
-}
\ No newline at end of file
+}
@@ -0,0 +1,181 @@
+package src
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// manifestEntry is a single download described by a structured --file
+// manifest.
+type manifestEntry struct {
+	URL       string   `yaml:"url" json:"url"`
+	Filename  string   `yaml:"filename,omitempty" json:"filename,omitempty"`
+	Integrity string   `yaml:"integrity,omitempty" json:"integrity,omitempty"`
+	Tags      []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+}
+
+// isManifestFile sniffs whether path points at a structured manifest (YAML or
+// JSONL) rather than the plain one-URL-per-line format, by extension first and
+// by leading bytes as a fallback.
+func isManifestFile(path string, firstLine string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json", ".jsonl":
+		return true
+	}
+
+	trimmed := strings.TrimSpace(firstLine)
+	return strings.HasPrefix(trimmed, "---") || strings.HasPrefix(trimmed, "{")
+}
+
+// readManifest parses a structured manifest file, auto-detecting JSONL (one
+// JSON object per line) versus a single YAML document containing a list of
+// entries.
+func readManifest(file *os.File) ([]manifestEntry, error) {
+	var entries []manifestEntry
+
+	scanner := bufio.NewScanner(file)
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	content := strings.Join(lines, "\n")
+	trimmed := strings.TrimSpace(content)
+
+	if strings.HasPrefix(trimmed, "{") {
+		// JSONL: one manifest entry per non-empty line
+		for _, line := range lines {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			var entry manifestEntry
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				return nil, fmt.Errorf("invalid manifest line %q: %w", line, err)
+			}
+			entries = append(entries, entry)
+		}
+		return entries, nil
+	}
+
+	if err := yaml.Unmarshal([]byte(content), &entries); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	return entries, nil
+}
+
+// matchesTags reports whether entry should be downloaded given the --tag and
+// --notag filters. An entry must carry at least one wanted tag (when any are
+// given) and must carry none of the excluded tags.
+func matchesTags(entry manifestEntry, want []string, exclude []string) bool {
+	for _, bad := range exclude {
+		for _, tag := range entry.Tags {
+			if tag == bad {
+				return false
+			}
+		}
+	}
+
+	if len(want) == 0 {
+		return true
+	}
+	for _, good := range want {
+		for _, tag := range entry.Tags {
+			if tag == good {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newDigestHash returns a hash.Hash for the algorithm named in a
+// "sha256:<hex>"-style integrity string.
+func newDigestHash(algo string) (hash.Hash, error) {
+	switch strings.ToLower(algo) {
+	case "sha256":
+		return sha256.New(), nil
+	case "sha512":
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported integrity algorithm: %s", algo)
+	}
+}
+
+// splitIntegrity splits an "algo:hexDigest" integrity string into its parts.
+// A bare hex digest with no "algo:" prefix falls back to defaultAlgo. The
+// digest is trimmed and lowercased so uppercase hex (common from tools like
+// Windows' certutil) and stray whitespace don't cause a false mismatch.
+func splitIntegrity(integrity string, defaultAlgo string) (algo string, digest string, err error) {
+	parts := strings.SplitN(integrity, ":", 2)
+	if len(parts) == 1 {
+		return defaultAlgo, strings.ToLower(strings.TrimSpace(parts[0])), nil
+	}
+	return parts[0], strings.ToLower(strings.TrimSpace(parts[1])), nil
+}
+
+// resolveDownloadLocation picks the destination path for a manifest entry,
+// preferring its explicit filename override over the URL's last path segment.
+func resolveDownloadLocation(dirPath string, entry manifestEntry) string {
+	name := entry.Filename
+	if name == "" {
+		split := strings.Split(entry.URL, "/")
+		name = split[len(split)-1]
+	}
+	return filepath.Clean(filepath.Join(dirPath, name))
+}
+
+// hashExistingPart folds the bytes already written to a resumed .part file
+// into digest before the remaining bytes are teed in as they're downloaded.
+func hashExistingPart(partPath string, digest hash.Hash) error {
+	existing, err := os.Open(partPath)
+	if err != nil {
+		return err
+	}
+	defer existing.Close()
+
+	_, err = io.Copy(digest, existing)
+	return err
+}
+
+// verifyFileIntegrity re-reads path and compares its digest against the
+// expected "algo:hexDigest" integrity value.
+func verifyFileIntegrity(path string, integrity string, defaultAlgo string) error {
+	algo, expected, err := splitIntegrity(integrity, defaultAlgo)
+	if err != nil {
+		return err
+	}
+
+	digest, err := newDigestHash(algo)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(digest, file); err != nil {
+		return err
+	}
+
+	if actual := fmt.Sprintf("%x", digest.Sum(nil)); actual != expected {
+		return fmt.Errorf("digest mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package src
+
+import "testing"
+
+func TestSplitIntegrity(t *testing.T) {
+	tests := []struct {
+		name        string
+		integrity   string
+		defaultAlgo string
+		wantAlgo    string
+		wantDigest  string
+	}{
+		{
+			name:        "explicit algo prefix",
+			integrity:   "sha512:abcd1234",
+			defaultAlgo: "sha256",
+			wantAlgo:    "sha512",
+			wantDigest:  "abcd1234",
+		},
+		{
+			name:        "bare digest falls back to defaultAlgo",
+			integrity:   "abcd1234",
+			defaultAlgo: "sha256",
+			wantAlgo:    "sha256",
+			wantDigest:  "abcd1234",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			algo, digest, err := splitIntegrity(tt.integrity, tt.defaultAlgo)
+			if err != nil {
+				t.Fatalf("splitIntegrity(%q, %q) returned error: %v", tt.integrity, tt.defaultAlgo, err)
+			}
+			if algo != tt.wantAlgo || digest != tt.wantDigest {
+				t.Errorf("splitIntegrity(%q, %q) = (%q, %q), want (%q, %q)", tt.integrity, tt.defaultAlgo, algo, digest, tt.wantAlgo, tt.wantDigest)
+			}
+		})
+	}
+}
+
+func TestMatchesTags(t *testing.T) {
+	entry := manifestEntry{URL: "https://example.com/video.mp4", Tags: []string{"hd", "trailer"}}
+
+	tests := []struct {
+		name    string
+		want    []string
+		exclude []string
+		matches bool
+	}{
+		{name: "no filters matches everything", matches: true},
+		{name: "wanted tag present", want: []string{"hd"}, matches: true},
+		{name: "wanted tag absent", want: []string{"4k"}, matches: false},
+		{name: "excluded tag present", exclude: []string{"trailer"}, matches: false},
+		{name: "exclude wins over want", want: []string{"hd"}, exclude: []string{"trailer"}, matches: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesTags(entry, tt.want, tt.exclude); got != tt.matches {
+				t.Errorf("matchesTags(%v, %v, %v) = %v, want %v", entry.Tags, tt.want, tt.exclude, got, tt.matches)
+			}
+		})
+	}
+}
+
+func TestIsManifestFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		firstLine string
+		want      bool
+	}{
+		{name: "yaml extension", path: "manifest.yaml", want: true},
+		{name: "yml extension", path: "manifest.yml", want: true},
+		{name: "json extension", path: "manifest.json", want: true},
+		{name: "jsonl extension", path: "manifest.jsonl", want: true},
+		{name: "plain list by extension", path: "urls.txt", want: false},
+		{name: "plain-list extension but yaml document marker", path: "urls.txt", firstLine: "---", want: true},
+		{name: "no extension but leading json brace", path: "urls", firstLine: `{"url": "https://example.com"}`, want: true},
+		{name: "no extension and plain url", path: "urls", firstLine: "https://example.com/video.mp4", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isManifestFile(tt.path, tt.firstLine); got != tt.want {
+				t.Errorf("isManifestFile(%q, %q) = %v, want %v", tt.path, tt.firstLine, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package src
+
+import (
+	"context"
+	"fmt"
+	"hash"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/cheggaaa/pb/v3"
+)
+
+// isRetryableStatus reports whether a response status is worth retrying:
+// rate limiting and server errors, but not client errors.
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// retryAfter parses a Retry-After header into a wait duration. Per RFC 9110
+// the header is either an integer number of seconds or an HTTP-date to wait
+// until; both forms are honored.
+func retryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		if wait := time.Until(when); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// backoffDelay computes base*2^attempt with jitter, capped at max.
+func backoffDelay(attempt int, base time.Duration, max time.Duration) time.Duration {
+	delay := base * time.Duration(int64(1)<<uint(attempt))
+	if delay <= 0 || delay > max {
+		delay = max
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay)/2+1))
+}
+
+// sleepWithJitter blocks for d or until ctx is cancelled, whichever comes first.
+func sleepWithJitter(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// retryRange retries fn, used by the chunked strategy where a failed attempt
+// simply refetches the same byte range from scratch.
+func retryRange(ctx context.Context, retries int, backoff time.Duration, maxBackoff time.Duration, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || ctx.Err() != nil || attempt >= retries {
+			return err
+		}
+		downloadEvent("retry", "", "", 0, 0, attempt+1)
+		sleepWithJitter(ctx, backoffDelay(attempt, backoff, maxBackoff))
+	}
+}
+
+// fetchWithRetry downloads url into out, honoring *offset as a resume point.
+// Network errors and 5xx/429 responses are retried with exponential backoff;
+// Retry-After is honored when the server sends one. *offset is advanced as
+// bytes land on disk so a retry after a mid-stream failure resumes instead of
+// starting over.
+func fetchWithRetry(ctx context.Context, url string, out *os.File, offset *int64, bar *pb.ProgressBar, digest hash.Hash, retries int, backoff time.Duration, maxBackoff time.Duration) error {
+	for attempt := 0; ; attempt++ {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+		if err != nil {
+			return err
+		}
+		if *offset > 0 {
+			request.Header.Set("Range", fmt.Sprintf("bytes=%d-", *offset))
+		}
+
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			if ctx.Err() != nil || attempt >= retries {
+				return err
+			}
+			downloadEvent("retry", url, "", 0, 0, attempt+1)
+			sleepWithJitter(ctx, backoffDelay(attempt, backoff, maxBackoff))
+			continue
+		}
+
+		if isRetryableStatus(response.StatusCode) {
+			response.Body.Close()
+			if ctx.Err() != nil || attempt >= retries {
+				return fmt.Errorf("giving up on %s after %d attempt(s): status %d", url, attempt+1, response.StatusCode)
+			}
+			wait := backoffDelay(attempt, backoff, maxBackoff)
+			if retryWait, ok := retryAfter(response.Header.Get("Retry-After")); ok {
+				wait = retryWait
+			}
+			downloadEvent("retry", url, "", 0, 0, attempt+1)
+			sleepWithJitter(ctx, wait)
+			continue
+		}
+		if response.StatusCode >= 400 {
+			response.Body.Close()
+			return fmt.Errorf("%s responded with status %d", url, response.StatusCode)
+		}
+
+		// The server may have stopped honoring our resume Range; restart
+		// the .part file from scratch rather than corrupting it
+		if *offset > 0 && response.StatusCode != http.StatusPartialContent {
+			if err := out.Truncate(0); err != nil {
+				response.Body.Close()
+				return err
+			}
+			if _, err := out.Seek(0, io.SeekStart); err != nil {
+				response.Body.Close()
+				return err
+			}
+			if digest != nil {
+				digest.Reset()
+			}
+			*offset = 0
+		}
+
+		if response.ContentLength >= 0 {
+			bar.SetTotal(*offset + response.ContentLength)
+		}
+
+		var reader io.Reader = bar.NewProxyReader(response.Body)
+		if digest != nil {
+			reader = io.TeeReader(reader, digest)
+		}
+
+		written, copyErr := io.Copy(out, reader)
+		response.Body.Close()
+		*offset += written
+
+		if copyErr == nil {
+			return nil
+		}
+		if ctx.Err() != nil || attempt >= retries {
+			return copyErr
+		}
+		downloadEvent("retry", url, "", written, 0, attempt+1)
+		sleepWithJitter(ctx, backoffDelay(attempt, backoff, maxBackoff))
+	}
+}
@@ -0,0 +1,32 @@
+package src
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffDelayCapsAtMax(t *testing.T) {
+	base := time.Second
+	max := 5 * time.Second
+
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := backoffDelay(attempt, base, max)
+		if delay <= 0 || delay > max {
+			t.Errorf("backoffDelay(%d, %v, %v) = %v, want in (0, %v]", attempt, base, max, delay, max)
+		}
+	}
+}
+
+func TestBackoffDelayGrowsWithAttempt(t *testing.T) {
+	base := 100 * time.Millisecond
+	max := time.Hour
+
+	// backoffDelay jitters within [delay/2, delay], so attempt 4's floor
+	// (base*16/2 = 800ms) lies above attempt 0's ceiling (base = 100ms) and the
+	// later delay always exceeds the earlier one.
+	first := backoffDelay(0, base, max)
+	later := backoffDelay(4, base, max)
+	if later <= first {
+		t.Errorf("expected backoffDelay to grow with attempt, got attempt 0 = %v, attempt 4 = %v", first, later)
+	}
+}